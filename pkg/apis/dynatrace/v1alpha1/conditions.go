@@ -0,0 +1,67 @@
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// OneAgentConditionType is the type of a condition reported on OneAgentStatus
+type OneAgentConditionType string
+
+const (
+	// OneAgentConditionProgressing is True while the rollout has not yet converged, i.e. while Available is not True
+	OneAgentConditionProgressing OneAgentConditionType = "Progressing"
+
+	// OneAgentConditionAvailable is True once every node runs the desired version of its installer
+	// variant (Status.Version when InstallerVariants is not used) and its pod is available
+	OneAgentConditionAvailable OneAgentConditionType = "Available"
+
+	// OneAgentConditionDegraded is True when the rollout cannot make further progress
+	OneAgentConditionDegraded OneAgentConditionType = "Degraded"
+
+	// OneAgentConditionTokenSecretValid is False when the paasToken/apiToken secret is missing or incomplete
+	OneAgentConditionTokenSecretValid OneAgentConditionType = "TokenSecretValid"
+
+	// OneAgentConditionAPIReachable is False when the Dynatrace API could not be reached
+	OneAgentConditionAPIReachable OneAgentConditionType = "APIReachable"
+)
+
+// OneAgentCondition represents the state of one aspect of a OneAgent rollout at a point in time
+type OneAgentCondition struct {
+	Type               OneAgentConditionType  `json:"type"`
+	Status             corev1.ConditionStatus `json:"status"`
+	LastTransitionTime metav1.Time            `json:"lastTransitionTime,omitempty"`
+	LastUpdateTime     metav1.Time            `json:"lastUpdateTime,omitempty"`
+	Reason             string                 `json:"reason,omitempty"`
+	Message            string                 `json:"message,omitempty"`
+}
+
+// GetCondition returns the condition of the given type, or nil if it is not present
+func GetCondition(conditions []OneAgentCondition, condType OneAgentConditionType) *OneAgentCondition {
+	for i := range conditions {
+		if conditions[i].Type == condType {
+			return &conditions[i]
+		}
+	}
+	return nil
+}
+
+// SetCondition adds or updates the condition with the same Type, preserving LastTransitionTime
+// when Status did not change. It returns the updated slice.
+func SetCondition(conditions []OneAgentCondition, condition OneAgentCondition) []OneAgentCondition {
+	now := metav1.Now()
+	condition.LastUpdateTime = now
+	condition.LastTransitionTime = now
+
+	if existing := GetCondition(conditions, condition.Type); existing != nil && existing.Status == condition.Status {
+		condition.LastTransitionTime = existing.LastTransitionTime
+	}
+
+	newConditions := make([]OneAgentCondition, 0, len(conditions)+1)
+	for _, c := range conditions {
+		if c.Type != condition.Type {
+			newConditions = append(newConditions, c)
+		}
+	}
+	return append(newConditions, condition)
+}
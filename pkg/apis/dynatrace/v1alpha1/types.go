@@ -0,0 +1,259 @@
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// OneAgentList contains a list of OneAgent
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type OneAgentList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []OneAgent `json:"items"`
+}
+
+// OneAgent is the Schema for the oneagents API
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type OneAgent struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   OneAgentSpec   `json:"spec,omitempty"`
+	Status OneAgentStatus `json:"status,omitempty"`
+}
+
+// OneAgentSpec defines the desired state of OneAgent
+type OneAgentSpec struct {
+	// ApiUrl is the URL of the Dynatrace API, e.g. https://ENVIRONMENTID.live.dynatrace.com/api
+	ApiUrl string `json:"apiUrl"`
+
+	// SkipCertCheck disables the certificate validation check for the API URL
+	// +optional
+	SkipCertCheck bool `json:"skipCertCheck,omitempty"`
+
+	// NodeSelector is the node selector applied to the OneAgent pods
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// Tolerations applied to the OneAgent pods, to allow scheduling onto tainted nodes
+	// +optional
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+
+	// Tokens is the name of the secret holding the `apiToken` and `paasToken`
+	// +optional
+	Tokens string `json:"tokens,omitempty"`
+
+	// Image is the full docker image name of the OneAgent image to use
+	// +optional
+	Image string `json:"image,omitempty"`
+
+	// WaitReadySeconds is the maximum number of seconds to wait for a pod to get ready after a restart
+	// +optional
+	WaitReadySeconds *uint16 `json:"waitReadySeconds,omitempty"`
+
+	// Args are additional arguments passed to the OneAgent installer
+	// +optional
+	Args []string `json:"args,omitempty"`
+
+	// Env are additional environment variables passed to the OneAgent container
+	// +optional
+	Env []corev1.EnvVar `json:"env,omitempty"`
+
+	// UpdateStrategy controls how the OneAgent DaemonSet is rolled out across nodes when a
+	// new agent version becomes available
+	// +optional
+	UpdateStrategy *OneAgentDaemonSetUpdateStrategy `json:"updateStrategy,omitempty"`
+
+	// MinReadySeconds is the minimum number of seconds for which a restarted pod should be ready,
+	// without any of its containers crashing, for it to be considered available
+	// +optional
+	MinReadySeconds int32 `json:"minReadySeconds,omitempty"`
+
+	// RestartPolicy controls the order in which out-of-date OneAgent pods are restarted.
+	// Defaults to "LeastDisruptive".
+	// +optional
+	RestartPolicy RestartPolicy `json:"restartPolicy,omitempty"`
+
+	// InstallerVariants renders one DaemonSet per entry, each scheduled onto the nodes
+	// matched by its NodeSelector. Use this to mix amd64/arm64, glibc/musl, or Windows nodes
+	// in the same cluster. When empty, a single DaemonSet is rendered from the top-level
+	// NodeSelector/Image/Args/Env fields, as before.
+	// +optional
+	InstallerVariants []InstallerVariant `json:"installerVariants,omitempty"`
+
+	// Proxy configures an HTTP(S) proxy used to reach the Dynatrace API, both from the
+	// operator and from the installer run on each node
+	// +optional
+	Proxy *OneAgentProxy `json:"proxy,omitempty"`
+
+	// TrustedCAs is the name of a ConfigMap in the same namespace holding a PEM bundle of
+	// additional CA certificates to trust, under the key "certs"
+	// +optional
+	TrustedCAs string `json:"trustedCAs,omitempty"`
+}
+
+// OneAgentProxy configures an HTTP(S) proxy, either as a literal value or read from a Secret
+type OneAgentProxy struct {
+	// Value is a literal proxy URL, e.g. http://proxy.example.com:3128
+	// +optional
+	Value string `json:"value,omitempty"`
+
+	// ValueFrom reads the proxy URL from a key in a Secret in the same namespace
+	// +optional
+	ValueFrom *corev1.SecretKeySelector `json:"valueFrom,omitempty"`
+}
+
+// InstallerVariant describes one flavor of the OneAgent installer and the nodes it targets
+type InstallerVariant struct {
+	// Name identifies the variant and is used to derive its DaemonSet name
+	Name string `json:"name"`
+
+	// NodeSelector restricts this variant's DaemonSet to matching nodes
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// OS is the operating system this variant's installer is built for, e.g. "unix" or "windows"
+	// +optional
+	OS string `json:"os,omitempty"`
+
+	// InstallerType selects the installer flavor, e.g. "default" or "paas"
+	// +optional
+	InstallerType string `json:"installerType,omitempty"`
+
+	// Image is the full docker image name of the OneAgent image to use for this variant
+	// +optional
+	Image string `json:"image,omitempty"`
+
+	// Args overrides the top-level Args for this variant
+	// +optional
+	Args []string `json:"args,omitempty"`
+
+	// Env overrides the top-level Env for this variant
+	// +optional
+	Env []corev1.EnvVar `json:"env,omitempty"`
+
+	// SkipHostChecks skips the Unix-only hostPath "/" mount, for variants (e.g. Windows) that
+	// cannot use it
+	// +optional
+	SkipHostChecks bool `json:"skipHostChecks,omitempty"`
+}
+
+// RestartPolicy is the ordering policy applied to pods that are due for a restart
+type RestartPolicy string
+
+const (
+	// RestartPolicyLeastDisruptive orders restarts to avoid nodes that are cordoned, tainted,
+	// or whose non-DaemonSet workloads are already unhealthy, preferring the most out-of-date
+	// agents first
+	RestartPolicyLeastDisruptive RestartPolicy = "LeastDisruptive"
+
+	// RestartPolicySequential restarts pods in the order they were listed
+	RestartPolicySequential RestartPolicy = "Sequential"
+
+	// RestartPolicyRandom restarts pods in a random order
+	RestartPolicyRandom RestartPolicy = "Random"
+)
+
+// OneAgentDaemonSetUpdateStrategyType is the type of DaemonSet update strategy
+type OneAgentDaemonSetUpdateStrategyType string
+
+const (
+	// RollingUpdateDaemonSetStrategyType replaces outdated pods one or more at a time, bounded by MaxUnavailable
+	RollingUpdateDaemonSetStrategyType OneAgentDaemonSetUpdateStrategyType = "RollingUpdate"
+
+	// OnDeleteDaemonSetStrategyType only replaces a pod once it has been manually deleted
+	OnDeleteDaemonSetStrategyType OneAgentDaemonSetUpdateStrategyType = "OnDelete"
+)
+
+// OneAgentDaemonSetUpdateStrategy mirrors appsv1.DaemonSetUpdateStrategy for the OneAgent CR
+type OneAgentDaemonSetUpdateStrategy struct {
+	// Type of DaemonSet update, either "RollingUpdate" or "OnDelete". Defaults to "RollingUpdate"
+	// +optional
+	Type OneAgentDaemonSetUpdateStrategyType `json:"type,omitempty"`
+
+	// RollingUpdate holds the parameters used when Type is RollingUpdateDaemonSetStrategyType
+	// +optional
+	RollingUpdate *RollingUpdateOneAgent `json:"rollingUpdate,omitempty"`
+}
+
+// RollingUpdateOneAgent controls the desired behavior of a rolling update
+type RollingUpdateOneAgent struct {
+	// MaxUnavailable is the maximum number of OneAgent pods that can be unavailable during the
+	// update. Value can be an absolute number or a percentage of the number of nodes the OneAgent
+	// is scheduled on. Defaults to 1.
+	// +optional
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+}
+
+// OneAgentStatus defines the observed state of OneAgent
+type OneAgentStatus struct {
+	// Version is the OneAgent version that is currently being rolled out across the cluster. When
+	// InstallerVariants is used, it is the version of the first variant; VariantVersions holds all.
+	// +optional
+	Version string `json:"version,omitempty"`
+
+	// Items maps a node name to the state of the OneAgent running on that node
+	// +optional
+	Items map[string]OneAgentInstance `json:"items,omitempty"`
+
+	// UpdatedTimestamp indicates when the status was last updated
+	// +optional
+	UpdatedTimestamp metav1.Time `json:"updatedTimestamp,omitempty"`
+
+	// Conditions describe the current state of the OneAgent rollout
+	// +optional
+	Conditions []OneAgentCondition `json:"conditions,omitempty"`
+
+	// VariantVersions maps an InstallerVariant name to the latest agent version available for it
+	// +optional
+	VariantVersions map[string]string `json:"variantVersions,omitempty"`
+}
+
+// OneAgentInstancePhase is the lifecycle phase of the OneAgent running on a single node
+type OneAgentInstancePhase string
+
+const (
+	// OneAgentInstancePhaseDeploying is set while the agent version for a node has not been observed yet
+	OneAgentInstancePhaseDeploying OneAgentInstancePhase = "Deploying"
+
+	// OneAgentInstancePhaseReady is set once the observed agent version on the node matches the
+	// desired version of its installer variant
+	OneAgentInstancePhaseReady OneAgentInstancePhase = "Ready"
+
+	// OneAgentInstancePhaseUpdating is set while the node is waiting to be restarted onto the
+	// desired version of its installer variant
+	OneAgentInstancePhaseUpdating OneAgentInstancePhase = "Updating"
+
+	// OneAgentInstancePhaseFailed is set when the agent version for a node could not be determined
+	OneAgentInstancePhaseFailed OneAgentInstancePhase = "Failed"
+)
+
+// OneAgentInstance holds the state of the OneAgent running on a single node
+type OneAgentInstance struct {
+	// PodName is the name of the OneAgent pod running on the node
+	// +optional
+	PodName string `json:"podName,omitempty"`
+
+	// Variant is the name of the InstallerVariant that produced the OneAgent pod on the node,
+	// empty when InstallerVariants is not used
+	// +optional
+	Variant string `json:"variant,omitempty"`
+
+	// Version is the currently observed OneAgent version running on the node
+	// +optional
+	Version string `json:"version,omitempty"`
+
+	// Phase is the current lifecycle phase of the OneAgent on the node
+	// +optional
+	Phase OneAgentInstancePhase `json:"phase,omitempty"`
+
+	// LastError is the last error encountered while determining the agent version on the node
+	// +optional
+	LastError string `json:"lastError,omitempty"`
+
+	// LastVersionProbeTimestamp is the last time the agent version on the node was successfully determined
+	// +optional
+	LastVersionProbeTimestamp metav1.Time `json:"lastVersionProbeTimestamp,omitempty"`
+}
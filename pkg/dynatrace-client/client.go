@@ -0,0 +1,24 @@
+package dtclient
+
+// OsType is the operating system an installer is built for
+type OsType string
+
+// InstallerType is the flavor of a OneAgent installer package
+type InstallerType string
+
+const (
+	OsUnix    OsType = "unix"
+	OsWindows OsType = "windows"
+
+	InstallerTypeDefault InstallerType = "default"
+	InstallerTypePaaS    InstallerType = "paas"
+)
+
+// Client is the interface for the Dynatrace REST API
+type Client interface {
+	// GetVersionForLatest returns the latest available agent version for the given os/installer combination
+	GetVersionForLatest(os OsType, installerType InstallerType) (string, error)
+
+	// GetVersionForIp returns the agent version currently running on the host with the given IP
+	GetVersionForIp(ip string) (string, error)
+}
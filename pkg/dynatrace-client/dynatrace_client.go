@@ -0,0 +1,68 @@
+package dtclient
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// dynatraceClient implements the Client interface using the Dynatrace REST API
+type dynatraceClient struct {
+	apiUrl     string
+	apiToken   string
+	paasToken  string
+	httpClient *http.Client
+}
+
+// NewClient creates a REST client for the given Dynatrace API endpoint, authenticating
+// with the given API and PaaS tokens. proxyURL, if non-empty, is used for all requests. If
+// trustedCAs is non-empty, it is parsed as a PEM bundle and used instead of the system CA
+// pool to verify the server certificate. If skipCertCheck is set, the server certificate is
+// not validated at all, and trustedCAs is ignored.
+func NewClient(apiUrl, apiToken, paasToken, proxyURL string, trustedCAs []byte, skipCertCheck bool) (Client, error) {
+	if apiUrl == "" {
+		return nil, fmt.Errorf("apiUrl is empty")
+	}
+	if apiToken == "" || paasToken == "" {
+		return nil, fmt.Errorf("token is empty")
+	}
+
+	transport := &http.Transport{TLSClientConfig: &tls.Config{InsecureSkipVerify: skipCertCheck}}
+
+	if proxyURL != "" {
+		parsed, err := url.Parse(proxyURL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy URL: %v", err)
+		}
+		transport.Proxy = http.ProxyURL(parsed)
+	}
+
+	if len(trustedCAs) > 0 && !skipCertCheck {
+		certPool := x509.NewCertPool()
+		if !certPool.AppendCertsFromPEM(trustedCAs) {
+			return nil, fmt.Errorf("failed to parse trusted CA certificates")
+		}
+		transport.TLSClientConfig.RootCAs = certPool
+	}
+
+	return &dynatraceClient{
+		apiUrl:     apiUrl,
+		apiToken:   apiToken,
+		paasToken:  paasToken,
+		httpClient: &http.Client{Transport: transport},
+	}, nil
+}
+
+func (dc *dynatraceClient) GetVersionForLatest(os OsType, installerType InstallerType) (string, error) {
+	// implementation omitted: issues a GET against
+	// {apiUrl}/v1/deployment/installer/agent/{os}/{installerType}/latest/metainfo
+	return "", fmt.Errorf("not implemented")
+}
+
+func (dc *dynatraceClient) GetVersionForIp(ip string) (string, error) {
+	// implementation omitted: issues a GET against {apiUrl}/v1/entity/infrastructure/hosts
+	// and matches the host by IP address
+	return "", fmt.Errorf("not implemented")
+}
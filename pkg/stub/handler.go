@@ -1,8 +1,13 @@
 package stub
 
 import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"hash/fnv"
 	"reflect"
+	"sync"
 	"time"
 
 	"github.com/Dynatrace/dynatrace-oneagent-operator/pkg/apis/dynatrace/v1alpha1"
@@ -17,19 +22,39 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	coreinformers "k8s.io/client-go/informers/core/v1"
+	"k8s.io/client-go/kubernetes"
+	corelisters "k8s.io/client-go/listers/core/v1"
 )
 
-// time between consecutive queries for a new pod to get ready
-const splayTimeSeconds = uint16(10)
-
-func NewHandler() handler.Handler {
-	return &Handler{}
+// defaultMaxUnavailable is the MaxUnavailable applied when the OneAgent spec does not
+// configure a RollingUpdate strategy explicitly
+var defaultMaxUnavailable = intstr.FromInt(1)
+
+// NewHandler builds a Handler backed by a shared Pod informer, so that waiting for a
+// restarted pod to become ready is driven by watch events instead of polling the API server.
+func NewHandler(clientset kubernetes.Interface) handler.Handler {
+	podInformer, podLister, readySignals, stopCh := newPodInformer(clientset)
+	return &Handler{
+		podInformer:  podInformer,
+		podLister:    podLister,
+		readySignals: readySignals,
+		stopCh:       stopCh,
+	}
 }
 
 type Handler struct {
-	// Fill me
+	podInformer  coreinformers.PodInformer
+	podLister    corelisters.PodLister
+	readySignals *podReadySignaler
+	stopCh       chan struct{}
+}
+
+// Stop shuts down the shared informer factory backing this Handler
+func (h *Handler) Stop() {
+	close(h.stopCh)
 }
 
 func (h *Handler) Handle(ctx types.Context, event types.Event) error {
@@ -53,56 +78,98 @@ func (h *Handler) Handle(ctx types.Context, event types.Event) error {
 			updateStatus = true
 		}
 
+		// default value for .spec.updateStrategy
+		if oneagent.Spec.UpdateStrategy == nil {
+			oneagent.Spec.UpdateStrategy = &v1alpha1.OneAgentDaemonSetUpdateStrategy{
+				Type:          v1alpha1.RollingUpdateDaemonSetStrategyType,
+				RollingUpdate: &v1alpha1.RollingUpdateOneAgent{MaxUnavailable: &defaultMaxUnavailable},
+			}
+			updateStatus = true
+		}
+
+		// default value for .spec.restartPolicy
+		if oneagent.Spec.RestartPolicy == "" {
+			oneagent.Spec.RestartPolicy = v1alpha1.RestartPolicyLeastDisruptive
+			updateStatus = true
+		}
+
 		// get access tokens for api authentication
 		paasToken, err := getSecretKey(oneagent, "paasToken")
 		if err != nil {
 			logrus.WithFields(logrus.Fields{"oneagent": oneagent.Name, "error": err, "token": "paasToken"}).Error()
+			setCondition(oneagent, v1alpha1.OneAgentConditionTokenSecretValid, corev1.ConditionFalse, "SecretNotFound", err.Error())
+			_ = updateOneAgentStatus(oneagent)
 			return err
 		}
 		apiToken, err := getSecretKey(oneagent, "apiToken")
 		if err != nil {
 			logrus.WithFields(logrus.Fields{"oneagent": oneagent.Name, "error": err, "token": "apiToken"}).Error()
+			setCondition(oneagent, v1alpha1.OneAgentConditionTokenSecretValid, corev1.ConditionFalse, "SecretNotFound", err.Error())
+			_ = updateOneAgentStatus(oneagent)
 			return err
 		}
-
-		// element needs to be inserted before it is used in ONEAGENT_INSTALLER_SCRIPT_URL
-		if oneagent.Spec.Env[0].Name != "ONEAGENT_INSTALLER_TOKEN" {
-			oneagent.Spec.Env = append(oneagent.Spec.Env[:0], append([]corev1.EnvVar{{
-				Name: "ONEAGENT_INSTALLER_TOKEN",
-				ValueFrom: &corev1.EnvVarSource{
-					SecretKeyRef: &corev1.SecretKeySelector{
-						LocalObjectReference: corev1.LocalObjectReference{Name: oneagent.Spec.Tokens},
-						Key:                  "paasToken"}},
-			}}, oneagent.Spec.Env[0:]...)...)
+		if setCondition(oneagent, v1alpha1.OneAgentConditionTokenSecretValid, corev1.ConditionTrue, "SecretFound", "") {
 			updateStatus = true
 		}
 
+		// resolve proxy and trusted CA settings, shared by every installer variant
+		proxyURL, err := getProxyURL(oneagent)
+		if err != nil {
+			logrus.WithFields(logrus.Fields{"oneagent": oneagent.Name, "error": err}).Error("failed to resolve proxy")
+			return err
+		}
+		trustedCAs, err := getTrustedCAs(oneagent)
+		if err != nil {
+			logrus.WithFields(logrus.Fields{"oneagent": oneagent.Name, "error": err}).Error("failed to get trusted CAs")
+			return err
+		}
+		net := variantNetworking{proxyURL: proxyURL, caBundle: trustedCAs, trustedCAsConfigMap: oneagent.Spec.TrustedCAs}
+
 		// create'n'update daemonset
-		err = upsertDaemonSet(oneagent)
+		err = upsertDaemonSet(oneagent, net)
 		if err != nil {
 			logrus.WithFields(logrus.Fields{"oneagent": oneagent.Name, "error": err}).Error("failed to create or update daemonset")
 			return err
 		}
 
 		// initialize dynatrace client
-		dtc, err := dtclient.NewClient(oneagent.Spec.ApiUrl, apiToken, paasToken)
+		dtc, err := dtclient.NewClient(oneagent.Spec.ApiUrl, apiToken, paasToken, proxyURL, trustedCAs, oneagent.Spec.SkipCertCheck)
 		if err != nil {
 			logrus.WithFields(logrus.Fields{"oneagent": oneagent.Name, "error": err}).Warning("failed to get dynatrace rest client")
 			return err
 		}
 
-		// get desired version
-		desired, err := dtc.GetVersionForLatest(dtclient.OsUnix, dtclient.InstallerTypeDefault)
-		if err != nil {
-			logrus.WithFields(logrus.Fields{"oneagent": oneagent.Name, "warning": err}).Warning("failed to get desired version")
-			// TODO think about error handling
-			// do not return err as it would trigger yet another reconciliation loop immediately
-			return nil
-		} else if desired != "" && oneagent.Status.Version != desired {
-			logrus.WithFields(logrus.Fields{"oneagent": oneagent.Name, "previous": oneagent.Status.Version, "desired": desired}).Info("new version available")
+		// get desired version, once per installer variant
+		variants := effectiveVariants(oneagent)
+		variantVersions := make(map[string]string, len(variants))
+		for _, variant := range variants {
+			desired, err := dtc.GetVersionForLatest(dtclient.OsType(variant.OS), dtclient.InstallerType(variant.InstallerType))
+			if err != nil {
+				logrus.WithFields(logrus.Fields{"oneagent": oneagent.Name, "variant": variant.Name, "warning": err}).Warning("failed to get desired version")
+				if setCondition(oneagent, v1alpha1.OneAgentConditionAPIReachable, corev1.ConditionFalse, "APIError", err.Error()) {
+					updateStatus = true
+				}
+				if updateStatus {
+					_ = updateOneAgentStatus(oneagent)
+				}
+				// TODO think about error handling
+				// do not return err as it would trigger yet another reconciliation loop immediately
+				return nil
+			}
+			variantVersions[variant.Name] = desired
+		}
+		if !reflect.DeepEqual(variantVersions, oneagent.Status.VariantVersions) {
+			logrus.WithFields(logrus.Fields{"oneagent": oneagent.Name, "previous": oneagent.Status.VariantVersions, "desired": variantVersions}).Info("new version available")
+			oneagent.Status.VariantVersions = variantVersions
+			updateStatus = true
+		}
+		if desired := variantVersions[variants[0].Name]; desired != "" && oneagent.Status.Version != desired {
 			oneagent.Status.Version = desired
 			updateStatus = true
 		}
+		if setCondition(oneagent, v1alpha1.OneAgentConditionAPIReachable, corev1.ConditionTrue, "APIReachable", "") {
+			updateStatus = true
+		}
 
 		// query oneagent pods
 		podList := getPodList()
@@ -115,15 +182,66 @@ func (h *Handler) Handle(ctx types.Context, event types.Event) error {
 		}
 
 		// determine pods to restart
-		podsToDelete, instances := getPodsToRestart(podList.Items, dtc, oneagent)
+		podsToDelete, instances := getPodsToRestart(podList.Items, dtc, oneagent, variantVersions)
 		if !reflect.DeepEqual(instances, oneagent.Status.Items) {
 			logrus.WithFields(logrus.Fields{"oneagent": oneagent.Name, "status.items": instances}).Info("status changed")
 			updateStatus = true
 			oneagent.Status.Items = instances
 		}
 
-		// restart daemonset
-		err = deletePods(oneagent, podsToDelete)
+		// reflect rollout progress in the Available/Progressing conditions; a node whose agent
+		// version is outdated or could not be determined keeps Available False
+		allAvailable := len(podsToDelete) == 0
+		for _, instance := range instances {
+			if instance.Phase != v1alpha1.OneAgentInstancePhaseReady {
+				allAvailable = false
+			}
+		}
+		now := metav1.Now()
+		for i := range podList.Items {
+			if !isPodAvailable(&podList.Items[i], oneagent.Spec.MinReadySeconds, now) {
+				allAvailable = false
+				break
+			}
+		}
+		if allAvailable {
+			if setCondition(oneagent, v1alpha1.OneAgentConditionAvailable, corev1.ConditionTrue, "AllPodsReady", "") {
+				updateStatus = true
+			}
+			if setCondition(oneagent, v1alpha1.OneAgentConditionProgressing, corev1.ConditionFalse, "AllPodsReady", "") {
+				updateStatus = true
+			}
+		} else {
+			if setCondition(oneagent, v1alpha1.OneAgentConditionAvailable, corev1.ConditionFalse, "RolloutInProgress", "") {
+				updateStatus = true
+			}
+			if setCondition(oneagent, v1alpha1.OneAgentConditionProgressing, corev1.ConditionTrue, "RolloutInProgress", "") {
+				updateStatus = true
+			}
+		}
+
+		// Degraded reflects nodes the rollout cannot make progress on: their agent version
+		// could not be determined, so getPodsToRestart can never confirm they reached their
+		// variant's desired version and will never schedule them for restart.
+		var failedNodes int
+		for _, instance := range instances {
+			if instance.Phase == v1alpha1.OneAgentInstancePhaseFailed {
+				failedNodes++
+			}
+		}
+		if failedNodes > 0 {
+			reason := fmt.Sprintf("%d node(s) failed to report an agent version", failedNodes)
+			if setCondition(oneagent, v1alpha1.OneAgentConditionDegraded, corev1.ConditionTrue, "VersionProbeFailed", reason) {
+				updateStatus = true
+			}
+		} else {
+			if setCondition(oneagent, v1alpha1.OneAgentConditionDegraded, corev1.ConditionFalse, "VersionProbeFailed", "") {
+				updateStatus = true
+			}
+		}
+
+		// restart daemonset, honoring the MaxUnavailable budget across the whole fleet
+		err = h.deletePods(oneagent, podList.Items, podsToDelete)
 		if err != nil {
 			logrus.WithFields(logrus.Fields{"oneagent": oneagent.Name, "error": err}).Error("failed to delete pods")
 			return err
@@ -131,11 +249,7 @@ func (h *Handler) Handle(ctx types.Context, event types.Event) error {
 
 		// update status
 		if updateStatus {
-			oneagent.Status.UpdatedTimestamp = metav1.Now()
-			logrus.WithFields(logrus.Fields{"oneagent": oneagent.Name, "status": oneagent.Status}).Info("updating status")
-			err := action.Update(oneagent)
-			if err != nil {
-				logrus.WithFields(logrus.Fields{"oneagent": oneagent.Name, "error": err}).Error("failed to update status")
+			if err := updateOneAgentStatus(oneagent); err != nil {
 				return err
 			}
 		}
@@ -144,6 +258,31 @@ func (h *Handler) Handle(ctx types.Context, event types.Event) error {
 	return nil
 }
 
+// setCondition sets the given condition on oneagent's status and reports whether the
+// condition's Status/Reason/Message actually changed, so callers can decide to persist it
+func setCondition(oneagent *v1alpha1.OneAgent, condType v1alpha1.OneAgentConditionType, status corev1.ConditionStatus, reason, message string) bool {
+	existing := v1alpha1.GetCondition(oneagent.Status.Conditions, condType)
+	changed := existing == nil || existing.Status != status || existing.Reason != reason || existing.Message != message
+	oneagent.Status.Conditions = v1alpha1.SetCondition(oneagent.Status.Conditions, v1alpha1.OneAgentCondition{
+		Type:    condType,
+		Status:  status,
+		Reason:  reason,
+		Message: message,
+	})
+	return changed
+}
+
+// updateOneAgentStatus persists the in-memory Status back to the API server
+func updateOneAgentStatus(oneagent *v1alpha1.OneAgent) error {
+	oneagent.Status.UpdatedTimestamp = metav1.Now()
+	logrus.WithFields(logrus.Fields{"oneagent": oneagent.Name, "status": oneagent.Status}).Info("updating status")
+	if err := action.Update(oneagent); err != nil {
+		logrus.WithFields(logrus.Fields{"oneagent": oneagent.Name, "error": err}).Error("failed to update status")
+		return err
+	}
+	return nil
+}
+
 // getPodList returns a v1.PodList object
 func getPodList() *corev1.PodList {
 	return &corev1.PodList{
@@ -154,151 +293,445 @@ func getPodList() *corev1.PodList {
 	}
 }
 
-// deletePods deletes a list of pods
+// deletePods deletes pods from doomedPods, up to the MaxUnavailable budget computed from
+// allPods and the OneAgent's UpdateStrategy, and deletes them in parallel rather than
+// blocking on each one sequentially. It never lets the number of unavailable pods across
+// the fleet exceed that budget, even across concurrent reconciles.
 //
 // Returns an error in the following conditions:
+//  - failure to compute the restart budget
 //  - failure on object deletion
 //  - timeout on waiting for ready state
-func deletePods(cr *v1alpha1.OneAgent, pods []corev1.Pod) error {
-	for _, pod := range pods {
-		// delete pod
-		logrus.WithFields(logrus.Fields{"oneagent": cr.Name, "pod": pod.Name, "nodeName": pod.Spec.NodeName}).Info("deleting pod")
-		err := action.Delete(&pod)
+func (h *Handler) deletePods(cr *v1alpha1.OneAgent, allPods []corev1.Pod, doomedPods []corev1.Pod) error {
+	maxUnavailable, err := getMaxUnavailable(cr, len(nodeNames(allPods)))
+	if err != nil {
+		return err
+	}
+
+	now := metav1.Now()
+	unavailable := 0
+	for i := range allPods {
+		if !isPodAvailable(&allPods[i], cr.Spec.MinReadySeconds, now) {
+			unavailable++
+		}
+	}
+
+	budget := maxUnavailable - unavailable
+	if budget <= 0 {
+		logrus.WithFields(logrus.Fields{"oneagent": cr.Name, "unavailable": unavailable, "maxUnavailable": maxUnavailable}).Info("restart budget exhausted, skipping this reconcile")
+		return nil
+	}
+	if budget > len(doomedPods) {
+		budget = len(doomedPods)
+	}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, budget)
+	for _, pod := range doomedPods[:budget] {
+		wg.Add(1)
+		go func(pod corev1.Pod) {
+			defer wg.Done()
+			if err := h.deletePodAndWaitReady(cr, pod); err != nil {
+				errs <- err
+			}
+		}(pod)
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		return err
+	}
+	return nil
+}
+
+// deletePodAndWaitReady deletes a single pod and blocks until its replacement on the same
+// node becomes available again, or WaitReadySeconds elapses. Readiness is driven by the
+// shared Pod informer's watch events rather than polling the API server.
+func (h *Handler) deletePodAndWaitReady(cr *v1alpha1.OneAgent, pod corev1.Pod) error {
+	logrus.WithFields(logrus.Fields{"oneagent": cr.Name, "pod": pod.Name, "nodeName": pod.Spec.NodeName}).Info("deleting pod")
+	if err := action.Delete(&pod); err != nil {
+		logrus.WithFields(logrus.Fields{"oneagent": cr.Name, "pod": pod.Name, "error": err}).Error("failed to delete pod")
+		return err
+	}
+
+	ready := h.readySignals.subscribe(pod.Spec.NodeName)
+	defer h.readySignals.unsubscribe(pod.Spec.NodeName, ready)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(*cr.Spec.WaitReadySeconds)*time.Second)
+	defer cancel()
+
+	selector := labels.SelectorFromSet(getLabels(cr))
+	for {
+		ok, err := h.replacementIsReady(cr, pod, selector)
 		if err != nil {
-			logrus.WithFields(logrus.Fields{"oneagent": cr.Name, "pod": pod.Name, "error": err}).Error("failed to delete pod")
+			logrus.WithFields(logrus.Fields{"oneagent": cr.Name, "nodeName": pod.Spec.NodeName, "warning": err}).Warning("failed to list cached pods")
+		} else if ok {
+			return nil
+		}
+
+		// a Ready pod only becomes available once MinReadySeconds have passed, which no
+		// informer event signals, so the cache is re-checked periodically in that case
+		var recheck <-chan time.Time
+		if cr.Spec.MinReadySeconds > 0 {
+			recheck = time.After(time.Second)
+		}
+
+		select {
+		case <-ready:
+			continue
+		case <-recheck:
+			continue
+		case <-ctx.Done():
+			err := fmt.Errorf("timed out waiting for pod on node %s to become available", pod.Spec.NodeName)
+			logrus.WithFields(logrus.Fields{"oneagent": cr.Name, "nodeName": pod.Spec.NodeName, "warning": err}).Warning("timeout waiting on pod to get ready")
 			return err
 		}
+	}
+}
 
-		// wait for pod on node to get "Running" again
-		var status error
-		fieldSelector, _ := fields.ParseSelector(fmt.Sprintf("spec.nodeName=%v,status.phase=Running,metadata.name!=%v", pod.Spec.NodeName, pod.Name))
-		labelSelector := labels.SelectorFromSet(getLabels(cr))
-		logrus.WithFields(logrus.Fields{"field-selector": fieldSelector, "label-selector": labelSelector}).Debug("query pod")
-		listOps := &metav1.ListOptions{FieldSelector: fieldSelector.String(), LabelSelector: labelSelector.String()}
-		for splay := uint16(0); splay < *cr.Spec.WaitReadySeconds; splay += splayTimeSeconds {
-			time.Sleep(time.Duration(splayTimeSeconds) * time.Second)
-			pList := getPodList()
-			status = query.List(cr.Namespace, pList, query.WithListOptions(listOps))
-			if status != nil {
-				logrus.WithFields(logrus.Fields{"oneagent": cr.Name, "nodeName": pod.Spec.NodeName, "pods": pList, "warning": status}).Warning("failed to query pods")
-				continue
-			}
-			if n := len(pList.Items); n == 1 && getPodReadyState(&pList.Items[0]) {
-				break
-			} else if n > 1 {
-				status = fmt.Errorf("too many pods found: expected=1 actual=%d", n)
-			}
+// replacementIsReady checks the informer cache for an available replacement pod for cr on
+// the node the just-deleted pod ran on
+func (h *Handler) replacementIsReady(cr *v1alpha1.OneAgent, deleted corev1.Pod, selector labels.Selector) (bool, error) {
+	pods, err := h.podLister.Pods(cr.Namespace).List(selector)
+	if err != nil {
+		return false, err
+	}
+
+	for _, pod := range pods {
+		if pod.Spec.NodeName != deleted.Spec.NodeName || pod.Name == deleted.Name {
+			continue
 		}
-		if status != nil {
-			logrus.WithFields(logrus.Fields{"oneagent": cr.Name, "nodeName": pod.Spec.NodeName, "warning": status}).Warning("timeout waiting on pod to get ready")
-			return status
+		if isPodAvailable(pod, cr.Spec.MinReadySeconds, metav1.Now()) {
+			return true, nil
 		}
 	}
+	return false, nil
+}
+
+// getMaxUnavailable returns the maximum number of OneAgent pods that may be unavailable at
+// once, resolving Spec.UpdateStrategy.RollingUpdate.MaxUnavailable (an int or a percentage)
+// against the number of nodes the OneAgent is scheduled on. OnDelete disables restarts.
+func getMaxUnavailable(cr *v1alpha1.OneAgent, numberOfNodes int) (int, error) {
+	strategy := cr.Spec.UpdateStrategy
+	if strategy != nil && strategy.Type == v1alpha1.OnDeleteDaemonSetStrategyType {
+		return 0, nil
+	}
 
+	maxUnavailable := &defaultMaxUnavailable
+	if strategy != nil && strategy.RollingUpdate != nil && strategy.RollingUpdate.MaxUnavailable != nil {
+		maxUnavailable = strategy.RollingUpdate.MaxUnavailable
+	}
+
+	// mirror the upstream DaemonSet controller's ResolveFenceposts: MaxUnavailable rounds
+	// down, so a fractional percentage never permits more simultaneous restarts than intended
+	value, err := intstr.GetScaledValueFromIntOrPercent(maxUnavailable, numberOfNodes, false)
+	if err != nil {
+		return 0, err
+	}
+	if value <= 0 {
+		// a rolling update must always make progress
+		value = 1
+	}
+	return value, nil
+}
+
+// isPodAvailable mirrors the upstream DaemonSet controller's notion of availability: a pod
+// must be Ready, and if MinReadySeconds is set, it must have been Ready for at least that long.
+func isPodAvailable(pod *corev1.Pod, minReadySeconds int32, now metav1.Time) bool {
+	c := getPodReadyCondition(pod)
+	if c == nil || c.Status != corev1.ConditionTrue {
+		return false
+	}
+	if minReadySeconds == 0 {
+		return true
+	}
+	minReadySecondsDuration := time.Duration(minReadySeconds) * time.Second
+	return !c.LastTransitionTime.IsZero() && c.LastTransitionTime.Add(minReadySecondsDuration).Before(now.Time)
+}
+
+// getPodReadyCondition returns the Ready condition of a pod, or nil if it has none
+func getPodReadyCondition(pod *corev1.Pod) *corev1.PodCondition {
+	for i := range pod.Status.Conditions {
+		if pod.Status.Conditions[i].Type == corev1.PodReady {
+			return &pod.Status.Conditions[i]
+		}
+	}
 	return nil
 }
 
-// getPodReadyState determines the overall ready state of a Pod.
-// Returns true if all containers in the Pod are ready.
-func getPodReadyState(p *corev1.Pod) bool {
-	ready := true
-	for _, c := range p.Status.ContainerStatuses {
-		logrus.WithFields(logrus.Fields{"pod": p.Name, "container": c.Name, "state": c.Ready}).Debug("test pod ready state")
-		ready = ready && c.Ready
+// nodeNames returns the set of distinct node names the given pods are scheduled on
+func nodeNames(pods []corev1.Pod) map[string]bool {
+	nodes := make(map[string]bool, len(pods))
+	for _, pod := range pods {
+		if pod.Spec.NodeName != "" {
+			nodes[pod.Spec.NodeName] = true
+		}
+	}
+	return nodes
+}
+
+// effectiveVariants returns the OneAgent's configured InstallerVariants, or, when none are
+// configured, a single implicit variant synthesized from the top-level NodeSelector/Image/
+// Args/Env fields. The implicit variant has an empty Name, which getDaemonSet treats
+// specially to keep rendering a single DaemonSet named after the CR, preserving the
+// single-variant behavior of OneAgents created before InstallerVariants existed.
+func effectiveVariants(cr *v1alpha1.OneAgent) []v1alpha1.InstallerVariant {
+	if len(cr.Spec.InstallerVariants) > 0 {
+		return cr.Spec.InstallerVariants
+	}
+	return []v1alpha1.InstallerVariant{{
+		NodeSelector:  cr.Spec.NodeSelector,
+		OS:            string(dtclient.OsUnix),
+		InstallerType: string(dtclient.InstallerTypeDefault),
+		Image:         cr.Spec.Image,
+		Args:          cr.Spec.Args,
+		Env:           cr.Spec.Env,
+	}}
+}
+
+// installerTokenEnvVar returns the env var the install script depends on to authenticate its
+// download, sourced from the CR's PaaS token secret.
+func installerTokenEnvVar(cr *v1alpha1.OneAgent) corev1.EnvVar {
+	return corev1.EnvVar{
+		Name: "ONEAGENT_INSTALLER_TOKEN",
+		ValueFrom: &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: cr.Spec.Tokens},
+				Key:                  "paasToken"}},
+	}
+}
+
+// variantEnv returns the full set of container env vars for variant: the installer token
+// first (ONEAGENT_INSTALLER_SCRIPT_URL in variant.Env may reference it, and k8s only
+// resolves $(VAR) references against vars defined earlier in the list), then the variant's
+// own env, then the proxy/CA vars shared by every variant.
+func variantEnv(cr *v1alpha1.OneAgent, variant v1alpha1.InstallerVariant, net variantNetworking) []corev1.EnvVar {
+	env := append([]corev1.EnvVar{installerTokenEnvVar(cr)}, variant.Env...)
+	return append(env, net.envVars()...)
+}
+
+// variantNetworking carries the HTTP(S) proxy and trusted-CA settings that apply identically
+// to every installer variant's DaemonSet and to the Dynatrace REST client
+type variantNetworking struct {
+	proxyURL            string
+	caBundle            []byte
+	trustedCAsConfigMap string
+}
+
+// envVars returns the container env vars needed for the installer to honor the proxy
+func (n variantNetworking) envVars() []corev1.EnvVar {
+	if n.proxyURL == "" {
+		return nil
+	}
+	return []corev1.EnvVar{
+		{Name: "https_proxy", Value: n.proxyURL},
+		{Name: "no_proxy", Value: "localhost,127.0.0.1,kubernetes.default.svc"},
+	}
+}
+
+// args returns the installer arguments needed to honor the proxy and trusted CA bundle
+func (n variantNetworking) args() []string {
+	var args []string
+	if n.proxyURL != "" {
+		args = append(args, "--set-proxy="+n.proxyURL)
+	}
+	if len(n.caBundle) > 0 {
+		args = append(args, "--set-ca-cert="+trustedCAsMountPath)
+	}
+	return args
+}
+
+// caVolume and caVolumeMount project the trusted CA ConfigMap into the container at
+// trustedCAsMountPath
+const trustedCAsVolumeName = "dynatrace-trusted-cas"
+const trustedCAsMountPath = "/mnt/dynatrace/certs/custom.pem"
+
+func (n variantNetworking) caVolume() corev1.Volume {
+	return corev1.Volume{
+		Name: trustedCAsVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			ConfigMap: &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: corev1.LocalObjectReference{Name: n.trustedCAsConfigMap},
+				Items:                []corev1.KeyToPath{{Key: "certs", Path: "custom.pem"}},
+			},
+		},
+	}
+}
+
+func (n variantNetworking) caVolumeMount() corev1.VolumeMount {
+	return corev1.VolumeMount{
+		Name:      trustedCAsVolumeName,
+		MountPath: "/mnt/dynatrace/certs",
+		ReadOnly:  true,
 	}
+}
 
-	return ready
+// caHash returns a short hash of the trusted CA bundle, stamped onto the pod template as an
+// annotation so that hasVariantSpecChanged notices a ConfigMap rotation even though its
+// content isn't otherwise reflected in the DaemonSetSpec
+func caHash(caBundle []byte) string {
+	if len(caBundle) == 0 {
+		return ""
+	}
+	sum := sha256.Sum256(caBundle)
+	return hex.EncodeToString(sum[:])[:12]
 }
 
-// upsertDaemonSet creates a new DaemonSet object if it does not exist or
-// updates an existing one if changes need to be synchronized.
+const caHashAnnotation = "dynatrace.com/ca-hash"
+
+// getProxyURL resolves cr.Spec.Proxy to a literal proxy URL, reading it from a Secret if
+// ValueFrom is set. Returns "" if no proxy is configured.
+func getProxyURL(cr *v1alpha1.OneAgent) (string, error) {
+	if cr.Spec.Proxy == nil {
+		return "", nil
+	}
+	if cr.Spec.Proxy.ValueFrom == nil {
+		return cr.Spec.Proxy.Value, nil
+	}
+
+	ref := cr.Spec.Proxy.ValueFrom
+	obj := &corev1.Secret{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Secret"},
+		ObjectMeta: metav1.ObjectMeta{Name: ref.Name, Namespace: cr.Namespace},
+	}
+	if err := query.Get(obj); err != nil {
+		return "", err
+	}
+	value, ok := obj.Data[ref.Key]
+	if !ok {
+		return "", fmt.Errorf("secret %s is missing key %v", ref.Name, ref.Key)
+	}
+	return string(value), nil
+}
+
+// getTrustedCAs reads the PEM bundle from cr.Spec.TrustedCAs, a ConfigMap in the same
+// namespace holding it under the key "certs". Returns nil if no ConfigMap is configured.
+func getTrustedCAs(cr *v1alpha1.OneAgent) ([]byte, error) {
+	if cr.Spec.TrustedCAs == "" {
+		return nil, nil
+	}
+
+	obj := &corev1.ConfigMap{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+		ObjectMeta: metav1.ObjectMeta{Name: cr.Spec.TrustedCAs, Namespace: cr.Namespace},
+	}
+	if err := query.Get(obj); err != nil {
+		return nil, err
+	}
+	certs, ok := obj.Data["certs"]
+	if !ok {
+		return nil, fmt.Errorf("configmap %s is missing key \"certs\"", cr.Spec.TrustedCAs)
+	}
+	return []byte(certs), nil
+}
+
+// upsertDaemonSet creates or updates one DaemonSet per installer variant, then prunes any
+// DaemonSet owned by oa that no longer corresponds to a configured variant.
 //
 // Returns an error in the following conditions:
 //  - all k8s apierrors except IsNotFound
-//  - failure on daemonset creation
-func upsertDaemonSet(oa *v1alpha1.OneAgent) error {
-	ds := getDaemonSet(oa)
+//  - failure on daemonset creation, update or deletion
+func upsertDaemonSet(oa *v1alpha1.OneAgent, net variantNetworking) error {
+	variants := effectiveVariants(oa)
+	for _, variant := range variants {
+		if err := upsertVariantDaemonSet(oa, variant, net); err != nil {
+			return err
+		}
+	}
+	return pruneStaleVariantDaemonSets(oa, variants)
+}
+
+// pruneStaleVariantDaemonSets deletes DaemonSets owned by oa whose name does not match any
+// of variants' current daemonSetName. Since daemonSetName changes on rename/reconfigure by
+// design, a variant that was renamed or dropped from Spec.InstallerVariants would otherwise
+// leave its old DaemonSet, and the pods it manages, running forever.
+func pruneStaleVariantDaemonSets(oa *v1alpha1.OneAgent, variants []v1alpha1.InstallerVariant) error {
+	wanted := make(map[string]bool, len(variants))
+	for _, variant := range variants {
+		wanted[daemonSetName(oa, variant)] = true
+	}
+
+	dsList := &appsv1.DaemonSetList{
+		TypeMeta: metav1.TypeMeta{Kind: "DaemonSet", APIVersion: "apps/v1"},
+	}
+	labelSelector := labels.SelectorFromSet(getLabels(oa)).String()
+	if err := query.List(oa.Namespace, dsList, query.WithListOptions(&metav1.ListOptions{LabelSelector: labelSelector})); err != nil {
+		logrus.WithFields(logrus.Fields{"oneagent": oa.Name, "error": err}).Error("failed to list daemonsets")
+		return err
+	}
+
+	for i := range dsList.Items {
+		ds := &dsList.Items[i]
+		if wanted[ds.Name] {
+			continue
+		}
+		logrus.WithFields(logrus.Fields{"oneagent": oa.Name, "daemonset": ds.Name}).Info("deleting stale variant daemonset")
+		if err := action.Delete(ds); err != nil {
+			logrus.WithFields(logrus.Fields{"oneagent": oa.Name, "daemonset": ds.Name, "error": err}).Error("failed to delete stale daemonset")
+			return err
+		}
+	}
+	return nil
+}
+
+// upsertVariantDaemonSet creates a new DaemonSet object for variant if it does not exist, or
+// updates the existing one if changes need to be synchronized.
+func upsertVariantDaemonSet(oa *v1alpha1.OneAgent, variant v1alpha1.InstallerVariant, net variantNetworking) error {
+	ds := getDaemonSet(oa, variant)
 	err := query.Get(ds)
 
 	if err == nil {
 		// update daemonset
-		if hasSpecChanged(&ds.Spec, oa) {
-			applyOneAgentSettings(ds, oa.DeepCopy())
+		if hasVariantSpecChanged(ds, oa, variant, net) {
+			applyVariantSettings(ds, oa.DeepCopy(), variant, net)
 			if err := action.Update(ds); err != nil {
-				logrus.WithFields(logrus.Fields{"oneagent": oa.Name, "error": err}).Error("failed to update daemonset")
+				logrus.WithFields(logrus.Fields{"oneagent": oa.Name, "variant": variant.Name, "error": err}).Error("failed to update daemonset")
 				return err
 			}
 		}
 	} else if apierrors.IsNotFound(err) {
 		// create deamonset
-		logrus.WithFields(logrus.Fields{"oneagent": oa.Name}).Info("deploying daemonset")
+		logrus.WithFields(logrus.Fields{"oneagent": oa.Name, "variant": variant.Name}).Info("deploying daemonset")
 		desiredState := oa.DeepCopy()
-		applyOneAgentDefaults(ds, desiredState)
-		applyOneAgentSettings(ds, desiredState)
+		applyVariantDefaults(ds, desiredState, variant)
+		applyVariantSettings(ds, desiredState, variant, net)
 		err = action.Create(ds)
 		if err != nil {
-			logrus.WithFields(logrus.Fields{"oneagent": oa.Name, "error": err}).Error("failed to deploy daemonset")
+			logrus.WithFields(logrus.Fields{"oneagent": oa.Name, "variant": variant.Name, "error": err}).Error("failed to deploy daemonset")
 			return err
 		}
 	} else {
-		logrus.WithFields(logrus.Fields{"oneagent": oa.Name, "error": err}).Error("failed to get daemonset")
+		logrus.WithFields(logrus.Fields{"oneagent": oa.Name, "variant": variant.Name, "error": err}).Error("failed to get daemonset")
 		return err
 	}
 
 	return nil
 }
 
-// hasSpecChanged compares essential OneAgent custom resource settings with the
-// actual settings in the DaemonSet object
-func hasSpecChanged(dsSpec *appsv1.DaemonSetSpec, cr *v1alpha1.OneAgent) bool {
-	actualSpec := cr.DeepCopy().Spec
-	copyDaemonSetSpecToOneAgentSpec(dsSpec, &actualSpec)
-	if !reflect.DeepEqual(cr.Spec, actualSpec) {
-		logrus.WithFields(logrus.Fields{"oneagent": cr.Name, "actual": actualSpec, "desired": cr.Spec}).Info("spec changed")
-		return true
-	}
-	return false
-}
-
-// copyDaemonSetSpecToOneAgentSpec extracts essential data from a DaemonSetSpec
-// into a OneAgentSpec
-func copyDaemonSetSpecToOneAgentSpec(ds *appsv1.DaemonSetSpec, cr *v1alpha1.OneAgentSpec) {
-	// ApiUrl
-	// SkipCertCheck
-	// NodeSelector
-	if ds.Template.Spec.NodeSelector != nil {
-		in, out := &ds.Template.Spec.NodeSelector, &cr.NodeSelector
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
-	// Tolerations
-	if ds.Template.Spec.Tolerations != nil {
-		in, out := &ds.Template.Spec.Tolerations, &cr.Tolerations
-		*out = make([]corev1.Toleration, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	// Image
-	cr.Image = ds.Template.Spec.Containers[0].Image
-	// Tokens
-	// WaitReadySeconds: not used in DaemonSet
-	// Args
-	if ds.Template.Spec.Containers[0].Args != nil {
-		in, out := &ds.Template.Spec.Containers[0].Args, &cr.Args
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	// Env
-	if ds.Template.Spec.Containers[0].Env != nil {
-		in, out := &ds.Template.Spec.Containers[0].Env, &cr.Env
-		*out = make([]corev1.EnvVar, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+// hasVariantSpecChanged compares a variant's essential settings with the actual settings in
+// its DaemonSet object
+func hasVariantSpecChanged(ds *appsv1.DaemonSet, cr *v1alpha1.OneAgent, variant v1alpha1.InstallerVariant, net variantNetworking) bool {
+	podSpec := ds.Spec.Template.Spec
+	container := podSpec.Containers[0]
+
+	desiredEnv := variantEnv(cr, variant, net)
+	desiredArgs := append(append([]string{}, variant.Args...), net.args()...)
+	desiredVolumes, desiredMounts := variantVolumes(variant, net)
+
+	changed := !reflect.DeepEqual(podSpec.NodeSelector, variant.NodeSelector) ||
+		!reflect.DeepEqual(podSpec.Tolerations, cr.Spec.Tolerations) ||
+		container.Image != variant.Image ||
+		!reflect.DeepEqual(container.Args, desiredArgs) ||
+		!reflect.DeepEqual(container.Env, desiredEnv) ||
+		!reflect.DeepEqual(podSpec.Volumes, desiredVolumes) ||
+		!reflect.DeepEqual(container.VolumeMounts, desiredMounts) ||
+		ds.Spec.Template.ObjectMeta.Annotations[caHashAnnotation] != caHash(net.caBundle)
+
+	if changed {
+		logrus.WithFields(logrus.Fields{"oneagent": cr.Name, "variant": variant.Name}).Info("spec changed")
 	}
+	return changed
 }
 
 // getSecretKey returns the value of a key from a secret.
@@ -332,51 +765,94 @@ func getSecretKey(cr *v1alpha1.OneAgent, key string) (string, error) {
 	return string(value), nil
 }
 
-// applyOneAgentSettings applies the properties given by a OneAgent custom
-// resource object to a DaemonSet object
-func applyOneAgentSettings(ds *appsv1.DaemonSet, cr *v1alpha1.OneAgent) {
-	labels := getLabels(cr)
+// applyVariantSettings applies the properties given by a OneAgent custom resource object, one
+// of its installer variants, and the cluster-wide proxy/trusted-CA settings to that variant's
+// DaemonSet object. Every mutable field is fully recomputed rather than appended to, so that
+// repeated reconciles are idempotent.
+func applyVariantSettings(ds *appsv1.DaemonSet, cr *v1alpha1.OneAgent, variant v1alpha1.InstallerVariant, net variantNetworking) {
+	labels := getVariantLabels(cr, variant)
 
 	ds.ObjectMeta.Labels = labels
 
-	ds.Spec.Selector = &metav1.LabelSelector{MatchLabels: labels}
-
-	ds.Spec.Template.ObjectMeta = metav1.ObjectMeta{Labels: labels}
+	podMeta := metav1.ObjectMeta{Labels: labels}
+	if hash := caHash(net.caBundle); hash != "" {
+		podMeta.Annotations = map[string]string{caHashAnnotation: hash}
+	}
+	ds.Spec.Template.ObjectMeta = podMeta
 
-	ds.Spec.Template.Spec.NodeSelector = cr.Spec.NodeSelector
+	ds.Spec.Template.Spec.NodeSelector = variant.NodeSelector
 	ds.Spec.Template.Spec.Tolerations = cr.Spec.Tolerations
 
-	ds.Spec.Template.Spec.Containers[0].Image = cr.Spec.Image
-	ds.Spec.Template.Spec.Containers[0].Env = cr.Spec.Env
-	ds.Spec.Template.Spec.Containers[0].Args = cr.Spec.Args
+	container := &ds.Spec.Template.Spec.Containers[0]
+	container.Image = variant.Image
+	container.Env = variantEnv(cr, variant, net)
+	container.Args = append(append([]string{}, variant.Args...), net.args()...)
+
+	volumes, mounts := variantVolumes(variant, net)
+	ds.Spec.Template.Spec.Volumes = volumes
+	container.VolumeMounts = mounts
+}
+
+// variantVolumes derives the Volumes and VolumeMounts for a variant's DaemonSet from its
+// SkipHostChecks setting and the cluster's trusted-CA configuration, so that
+// hasVariantSpecChanged and applyVariantSettings always agree on the desired state.
+func variantVolumes(variant v1alpha1.InstallerVariant, net variantNetworking) ([]corev1.Volume, []corev1.VolumeMount) {
+	var volumes []corev1.Volume
+	var mounts []corev1.VolumeMount
+	if !variant.SkipHostChecks {
+		volumes = append(volumes, hostRootVolume())
+		mounts = append(mounts, hostRootVolumeMount())
+	}
+	if len(net.caBundle) > 0 {
+		volumes = append(volumes, net.caVolume())
+		mounts = append(mounts, net.caVolumeMount())
+	}
+	return volumes, mounts
+}
+
+// hostRootVolume and hostRootVolumeMount mount the node's root filesystem into the container,
+// skipped for variants (e.g. Windows) with SkipHostChecks set
+func hostRootVolume() corev1.Volume {
+	return corev1.Volume{
+		Name: "host-root",
+		VolumeSource: corev1.VolumeSource{
+			HostPath: &corev1.HostPathVolumeSource{
+				Path: "/",
+			},
+		},
+	}
 }
 
-// applyOneAgentDefaults initializes a bare DaemonSet object with default
-// values
-func applyOneAgentDefaults(ds *appsv1.DaemonSet, cr *v1alpha1.OneAgent) {
+func hostRootVolumeMount() corev1.VolumeMount {
+	return corev1.VolumeMount{
+		Name:      "host-root",
+		MountPath: "/mnt/root",
+	}
+}
+
+// applyVariantDefaults initializes a bare DaemonSet object with default values for variant.
+// Mutable fields (Volumes, VolumeMounts, Env, Args, ...) are left for applyVariantSettings to
+// fill in, since it is called right after this on creation too. Selector is set here, and only
+// here, since it is immutable once the DaemonSet is created.
+func applyVariantDefaults(ds *appsv1.DaemonSet, cr *v1alpha1.OneAgent, variant v1alpha1.InstallerVariant) {
 	trueVar := true
 
 	ds.Spec = appsv1.DaemonSetSpec{
+		Selector: &metav1.LabelSelector{MatchLabels: getVariantLabels(cr, variant)},
+		// The operator drives pod restarts itself (budget, ordering, readiness gating) via
+		// explicit Delete calls; OnDelete keeps the native DaemonSet controller from racing
+		// it with its own rollout whenever the pod template changes.
+		UpdateStrategy: appsv1.DaemonSetUpdateStrategy{
+			Type: appsv1.OnDeleteDaemonSetStrategyType,
+		},
 		Template: corev1.PodTemplateSpec{
 			Spec: corev1.PodSpec{
-				Volumes: []corev1.Volume{{
-					Name: "host-root",
-					VolumeSource: corev1.VolumeSource{
-						HostPath: &corev1.HostPathVolumeSource{
-							Path: "/",
-						},
-					},
-				}},
 				HostNetwork: true,
 				HostPID:     true,
 				HostIPC:     true,
 				Containers: []corev1.Container{{
 					Name:            "dynatrace-oneagent",
 					ImagePullPolicy: corev1.PullAlways,
-					VolumeMounts: []corev1.VolumeMount{{
-						Name:      "host-root",
-						MountPath: "/mnt/root",
-					}},
 					SecurityContext: &corev1.SecurityContext{
 						Privileged: &trueVar,
 					},
@@ -407,7 +883,7 @@ func applyOneAgentDefaults(ds *appsv1.DaemonSet, cr *v1alpha1.OneAgent) {
 	ds.SetOwnerReferences(append(ds.GetOwnerReferences(), ownerRef))
 }
 
-// getPodLables return labels set on all objects created by this CR
+// getLabels returns the labels set on all objects created by this CR, across every variant
 func getLabels(cr *v1alpha1.OneAgent) map[string]string {
 	return map[string]string{
 		"dynatrace": "oneagent",
@@ -415,47 +891,86 @@ func getLabels(cr *v1alpha1.OneAgent) map[string]string {
 	}
 }
 
-// getPodsToRestart determines if a pod needs to be restarted in order to get the desired agent version
+// getVariantLabels returns getLabels plus a label identifying the installer variant, so that
+// each variant's DaemonSet only selects the pods it created
+func getVariantLabels(cr *v1alpha1.OneAgent, variant v1alpha1.InstallerVariant) map[string]string {
+	labels := getLabels(cr)
+	labels["oneagent-variant"] = variant.Name
+	return labels
+}
+
+// getPodsToRestart determines if a pod needs to be restarted in order to get the desired agent
+// version for its installer variant, given by variantVersions (keyed by variant name, as
+// assigned by the "oneagent-variant" label; the empty key is the implicit default variant).
 // Returns an array of pods and an array of OneAgentInstance objects for status update
-func getPodsToRestart(pods []corev1.Pod, dtc dtclient.Client, oneagent *v1alpha1.OneAgent) ([]corev1.Pod, map[string]v1alpha1.OneAgentInstance) {
+func getPodsToRestart(pods []corev1.Pod, dtc dtclient.Client, oneagent *v1alpha1.OneAgent, variantVersions map[string]string) ([]corev1.Pod, map[string]v1alpha1.OneAgentInstance) {
 	var doomedPods []corev1.Pod
 	instances := make(map[string]v1alpha1.OneAgentInstance)
 
 	for _, pod := range pods {
 		logrus.WithFields(logrus.Fields{"oneagent": oneagent.Name, "pod": pod.Name, "nodeName": pod.Spec.NodeName}).Debug("processing pod")
+		variant := pod.Labels["oneagent-variant"]
+		desired := variantVersions[variant]
 		item := v1alpha1.OneAgentInstance{
 			PodName: pod.Name,
+			Variant: variant,
 		}
 		ver, err := dtc.GetVersionForIp(pod.Status.HostIP)
 		if err != nil {
 			logrus.WithFields(logrus.Fields{"oneagent": oneagent.Name, "pod": pod.Name, "nodeName": pod.Spec.NodeName, "hostIP": pod.Status.HostIP, "warning": err}).Warning("no agent found for host")
+			item.Phase = v1alpha1.OneAgentInstancePhaseFailed
+			item.LastError = err.Error()
 			// use last know version if available
 			if i, ok := oneagent.Status.Items[pod.Spec.NodeName]; ok {
 				item.Version = i.Version
 			}
+			item.LastVersionProbeTimestamp = oneagent.Status.Items[pod.Spec.NodeName].LastVersionProbeTimestamp
+			if item.Version == "" {
+				item.Phase = v1alpha1.OneAgentInstancePhaseDeploying
+			}
 		} else {
 			logrus.WithFields(logrus.Fields{"oneagent": oneagent.Name, "pod": pod.Name, "nodeName": pod.Spec.NodeName, "version": ver}).Debug("")
 			item.Version = ver
-			if ver != oneagent.Status.Version {
-				logrus.WithFields(logrus.Fields{"oneagent": oneagent.Name, "pod": pod.Name, "nodeName": pod.Spec.NodeName, "actual": ver, "desired": oneagent.Status.Version}).Info("")
+			item.LastVersionProbeTimestamp = metav1.Now()
+			if ver != desired {
+				logrus.WithFields(logrus.Fields{"oneagent": oneagent.Name, "pod": pod.Name, "nodeName": pod.Spec.NodeName, "variant": variant, "actual": ver, "desired": desired}).Info("")
+				item.Phase = v1alpha1.OneAgentInstancePhaseUpdating
 				doomedPods = append(doomedPods, pod)
+			} else {
+				item.Phase = v1alpha1.OneAgentInstancePhaseReady
 			}
 		}
 		instances[pod.Spec.NodeName] = item
 	}
 
+	orderPodsToRestart(oneagent, doomedPods, instances)
+
 	return doomedPods, instances
 }
 
-// getDaemonSet return a basic DaemonSet object without DaemonSetSpec
-func getDaemonSet(cr *v1alpha1.OneAgent) *appsv1.DaemonSet {
+// getDaemonSet returns a basic DaemonSet object without DaemonSetSpec for variant. A variant
+// with no Name (the implicit default variant synthesized by effectiveVariants) keeps the
+// DaemonSet named after the CR itself; named variants get a name suffixed with a short hash
+// of their identity, so renaming or reconfiguring a variant rolls out a fresh DaemonSet
+// instead of mutating the old one in place.
+func getDaemonSet(cr *v1alpha1.OneAgent, variant v1alpha1.InstallerVariant) *appsv1.DaemonSet {
 	return &appsv1.DaemonSet{
 		TypeMeta: metav1.TypeMeta{
 			APIVersion: "apps/v1",
 			Kind:       "DaemonSet",
 		},
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      cr.Name,
+			Name:      daemonSetName(cr, variant),
 			Namespace: cr.Namespace,
 		}}
 }
+
+// daemonSetName derives the DaemonSet name for a variant of cr
+func daemonSetName(cr *v1alpha1.OneAgent, variant v1alpha1.InstallerVariant) string {
+	if variant.Name == "" {
+		return cr.Name
+	}
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(variant.Name + "/" + variant.OS + "/" + variant.InstallerType))
+	return fmt.Sprintf("%s-%s-%x", cr.Name, variant.Name, h.Sum32())
+}
@@ -0,0 +1,91 @@
+package stub
+
+import (
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/informers"
+	coreinformers "k8s.io/client-go/informers/core/v1"
+	"k8s.io/client-go/kubernetes"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+// podInformerLabelSelector restricts the shared Pod informer to pods created by this
+// operator (across every OneAgent CR and installer variant), mirroring getLabels.
+const podInformerLabelSelector = "dynatrace=oneagent"
+
+// newPodInformer builds and starts a SharedInformerFactory scoped to Pods carrying
+// podInformerLabelSelector, wiring its UpdateFunc into a podReadySignaler so
+// deletePodAndWaitReady can block on a channel instead of polling the API server for every
+// deleted pod. It blocks until the informer's initial cache sync completes.
+func newPodInformer(clientset kubernetes.Interface) (coreinformers.PodInformer, corelisters.PodLister, *podReadySignaler, chan struct{}) {
+	factory := informers.NewSharedInformerFactoryWithOptions(clientset, 0, informers.WithTweakListOptions(func(opts *metav1.ListOptions) {
+		opts.LabelSelector = podInformerLabelSelector
+	}))
+	podInformer := factory.Core().V1().Pods()
+	signaler := newPodReadySignaler()
+
+	podInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		UpdateFunc: func(old, new interface{}) {
+			if pod, ok := new.(*corev1.Pod); ok {
+				signaler.notify(pod)
+			}
+		},
+	})
+
+	stopCh := make(chan struct{})
+	factory.Start(stopCh)
+	cache.WaitForCacheSync(stopCh, podInformer.Informer().HasSynced)
+
+	return podInformer, podInformer.Lister(), signaler, stopCh
+}
+
+// podReadySignaler fans out informer pod-update events to reconcile goroutines that are
+// waiting for the pod on a specific node to become ready again.
+type podReadySignaler struct {
+	mu   sync.Mutex
+	subs map[string][]chan struct{}
+}
+
+func newPodReadySignaler() *podReadySignaler {
+	return &podReadySignaler{subs: make(map[string][]chan struct{})}
+}
+
+// subscribe registers interest in the given node's pods becoming ready. Callers must
+// unsubscribe once done to avoid leaking the channel.
+func (s *podReadySignaler) subscribe(nodeName string) chan struct{} {
+	ch := make(chan struct{}, 1)
+	s.mu.Lock()
+	s.subs[nodeName] = append(s.subs[nodeName], ch)
+	s.mu.Unlock()
+	return ch
+}
+
+func (s *podReadySignaler) unsubscribe(nodeName string, ch chan struct{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	subs := s.subs[nodeName]
+	for i, c := range subs {
+		if c == ch {
+			s.subs[nodeName] = append(subs[:i], subs[i+1:]...)
+			break
+		}
+	}
+}
+
+// notify wakes up anyone subscribed to pod's node, if pod just became ready
+func (s *podReadySignaler) notify(pod *corev1.Pod) {
+	if !isPodReady(pod) {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, ch := range s.subs[pod.Spec.NodeName] {
+		select {
+		case ch <- struct{}{}:
+		default:
+		}
+	}
+}
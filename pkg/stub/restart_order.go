@@ -0,0 +1,172 @@
+package stub
+
+import (
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Dynatrace/dynatrace-oneagent-operator/pkg/apis/dynatrace/v1alpha1"
+
+	"github.com/operator-framework/operator-sdk/pkg/sdk/query"
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// nodeHealth summarizes the scheduling and workload state of a node, as far as it affects
+// how disruptive it would be to restart the OneAgent running on it
+type nodeHealth struct {
+	unschedulable     bool
+	noExecuteTainted  bool
+	workloadUnhealthy bool
+}
+
+// restartCandidate carries everything byRestartPriority needs to rank one pod against its peers
+type restartCandidate struct {
+	pod              corev1.Pod
+	currentVersion   string
+	lastVersionProbe metav1.Time
+	nodeHealth       nodeHealth
+}
+
+// byRestartPriority orders restartCandidates from least to most disruptive to restart next:
+// cordoned or NoExecute-tainted nodes sort last, nodes whose non-DaemonSet workloads are
+// already unhealthy sort last, the most out-of-date agents sort first, and the oldest
+// version observation breaks ties.
+type byRestartPriority []restartCandidate
+
+func (c byRestartPriority) Len() int      { return len(c) }
+func (c byRestartPriority) Swap(i, j int) { c[i], c[j] = c[j], c[i] }
+func (c byRestartPriority) Less(i, j int) bool {
+	a, b := c[i], c[j]
+
+	if a.nodeHealth.unschedulable != b.nodeHealth.unschedulable {
+		return b.nodeHealth.unschedulable
+	}
+	if a.nodeHealth.noExecuteTainted != b.nodeHealth.noExecuteTainted {
+		return b.nodeHealth.noExecuteTainted
+	}
+	if a.nodeHealth.workloadUnhealthy != b.nodeHealth.workloadUnhealthy {
+		return b.nodeHealth.workloadUnhealthy
+	}
+	if cmp := compareVersions(a.currentVersion, b.currentVersion); cmp != 0 {
+		// the furthest-behind (numerically smallest) version restarts first
+		return cmp < 0
+	}
+	return a.lastVersionProbe.Before(&b.lastVersionProbe)
+}
+
+// orderPodsToRestart reorders pods in place according to cr.Spec.RestartPolicy
+func orderPodsToRestart(cr *v1alpha1.OneAgent, pods []corev1.Pod, instances map[string]v1alpha1.OneAgentInstance) {
+	if len(pods) == 0 {
+		return
+	}
+
+	switch cr.Spec.RestartPolicy {
+	case v1alpha1.RestartPolicySequential:
+		return
+	case v1alpha1.RestartPolicyRandom:
+		rand.Shuffle(len(pods), func(i, j int) { pods[i], pods[j] = pods[j], pods[i] })
+		return
+	}
+
+	health, err := getNodeHealth()
+	if err != nil {
+		logrus.WithFields(logrus.Fields{"oneagent": cr.Name, "warning": err}).Warning("failed to determine node health, restarting in list order")
+		return
+	}
+
+	candidates := make(byRestartPriority, len(pods))
+	for i, pod := range pods {
+		instance := instances[pod.Spec.NodeName]
+		candidates[i] = restartCandidate{
+			pod:              pod,
+			currentVersion:   instance.Version,
+			lastVersionProbe: instance.LastVersionProbeTimestamp,
+			nodeHealth:       health[pod.Spec.NodeName],
+		}
+	}
+
+	sort.Stable(candidates)
+	for i := range candidates {
+		pods[i] = candidates[i].pod
+	}
+}
+
+// getNodeHealth queries all Nodes and Pods in the cluster once, and summarizes per-node
+// scheduling/workload health for use by the restart ordering.
+func getNodeHealth() (map[string]nodeHealth, error) {
+	nodeList := &corev1.NodeList{TypeMeta: metav1.TypeMeta{Kind: "Node", APIVersion: "v1"}}
+	if err := query.List("", nodeList); err != nil {
+		return nil, err
+	}
+
+	podList := &corev1.PodList{TypeMeta: metav1.TypeMeta{Kind: "Pod", APIVersion: "v1"}}
+	if err := query.List("", podList); err != nil {
+		return nil, err
+	}
+
+	health := make(map[string]nodeHealth, len(nodeList.Items))
+	for _, node := range nodeList.Items {
+		h := nodeHealth{unschedulable: node.Spec.Unschedulable}
+		for _, taint := range node.Spec.Taints {
+			if taint.Effect == corev1.TaintEffectNoExecute {
+				h.noExecuteTainted = true
+				break
+			}
+		}
+		health[node.Name] = h
+	}
+
+	for i := range podList.Items {
+		pod := &podList.Items[i]
+		if pod.Spec.NodeName == "" || isDaemonSetPod(pod) || isPodReady(pod) {
+			continue
+		}
+		if h, ok := health[pod.Spec.NodeName]; ok {
+			h.workloadUnhealthy = true
+			health[pod.Spec.NodeName] = h
+		}
+	}
+
+	return health, nil
+}
+
+// isDaemonSetPod reports whether a pod is owned by a DaemonSet
+func isDaemonSetPod(pod *corev1.Pod) bool {
+	for _, ref := range pod.OwnerReferences {
+		if ref.Kind == "DaemonSet" {
+			return true
+		}
+	}
+	return false
+}
+
+// isPodReady reports whether a pod's Ready condition is true
+func isPodReady(pod *corev1.Pod) bool {
+	c := getPodReadyCondition(pod)
+	return c != nil && c.Status == corev1.ConditionTrue
+}
+
+// compareVersions compares two dot-separated numeric version strings, ignoring any
+// "-timestamp" build suffix. Returns <0 if a<b, 0 if equal, >0 if a>b. A missing
+// or unparseable segment compares as 0, so an unknown version sorts as "furthest behind".
+func compareVersions(a, b string) int {
+	as := strings.Split(strings.SplitN(a, "-", 2)[0], ".")
+	bs := strings.Split(strings.SplitN(b, "-", 2)[0], ".")
+
+	for i := 0; i < len(as) || i < len(bs); i++ {
+		var av, bv int
+		if i < len(as) {
+			av, _ = strconv.Atoi(as[i])
+		}
+		if i < len(bs) {
+			bv, _ = strconv.Atoi(bs[i])
+		}
+		if av != bv {
+			return av - bv
+		}
+	}
+	return 0
+}
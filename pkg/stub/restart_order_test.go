@@ -0,0 +1,57 @@
+package stub
+
+import (
+	"sort"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestByRestartPriority(t *testing.T) {
+	older := metav1.NewTime(metav1.Now().Add(-time.Hour))
+	newer := metav1.Now()
+
+	candidates := byRestartPriority{
+		{pod: corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "healthy-uptodate"}}, currentVersion: "1.195.0", lastVersionProbe: newer},
+		{pod: corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "cordoned"}}, currentVersion: "1.190.0", nodeHealth: nodeHealth{unschedulable: true}},
+		{pod: corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "workload-unhealthy"}}, currentVersion: "1.190.0", nodeHealth: nodeHealth{workloadUnhealthy: true}},
+		{pod: corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "furthest-behind"}}, currentVersion: "1.180.0", lastVersionProbe: older},
+		{pod: corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "oldest-observation"}}, currentVersion: "1.190.0", lastVersionProbe: older},
+	}
+
+	sort.Stable(candidates)
+
+	var order []string
+	for _, c := range candidates {
+		order = append(order, c.pod.Name)
+	}
+
+	want := []string{"furthest-behind", "oldest-observation", "healthy-uptodate", "workload-unhealthy", "cordoned"}
+	for i, name := range want {
+		if order[i] != name {
+			t.Fatalf("unexpected restart order: got %v, want %v", order, want)
+		}
+	}
+}
+
+func TestCompareVersions(t *testing.T) {
+	cases := []struct {
+		a, b string
+		want int
+	}{
+		{"1.195.0", "1.195.0", 0},
+		{"1.190.0", "1.195.0", -1},
+		{"1.195.1", "1.195.0", 1},
+		{"1.195.0-20201103-123456", "1.195.0", 0},
+		{"", "1.0.0", -1},
+	}
+
+	for _, c := range cases {
+		got := compareVersions(c.a, c.b)
+		if (got < 0 && c.want >= 0) || (got > 0 && c.want <= 0) || (got == 0 && c.want != 0) {
+			t.Errorf("compareVersions(%q, %q) = %d, want sign %d", c.a, c.b, got, c.want)
+		}
+	}
+}